@@ -0,0 +1,119 @@
+package main
+
+import (
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+
+	"cursor/tokenpool"
+)
+
+// adminAuth guards the /admin routes with a single shared password, read
+// from ADMIN_PASSWORD at startup. If the env var is unset, admin routes are
+// disabled entirely rather than left open.
+func adminAuth(password string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if password == "" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "admin routes are disabled"})
+			c.Abort()
+			return
+		}
+		if c.GetHeader("Authorization") != "Bearer "+password {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid admin credentials"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// registerAdminRoutes wires the token-pool and API-key admin APIs under
+// /admin, protected by adminAuth.
+func registerAdminRoutes(r *gin.Engine, pool *tokenpool.Pool, keys *KeyStore) {
+	admin := r.Group("/admin", adminAuth(os.Getenv("ADMIN_PASSWORD")))
+
+	// POST /admin/tokens/har - multipart upload of a HAR file recorded from
+	// a logged-in browser session; replaces the pool's tokens.
+	admin.POST("/tokens/har", func(c *gin.Context) {
+		file, err := c.FormFile("har")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "missing \"har\" file"})
+			return
+		}
+
+		tmp, err := os.CreateTemp("", "upload-*.har")
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		defer os.Remove(tmp.Name())
+
+		if err := c.SaveUploadedFile(file, tmp.Name()); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if err := pool.LoadFile(tmp.Name()); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"tokens": pool.List()})
+	})
+
+	// GET /admin/tokens lists each pooled token's health.
+	admin.GET("/tokens", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"tokens": pool.List()})
+	})
+
+	// POST /admin/keys { "quota": 100000 } - provisions a new API key with
+	// the given daily token quota (0 means unlimited).
+	admin.POST("/keys", func(c *gin.Context) {
+		var req struct {
+			Quota int64 `json:"quota"`
+		}
+		_ = c.BindJSON(&req)
+
+		key, err := keys.Create(req.Quota)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, key)
+	})
+
+	// GET /admin/keys lists every provisioned key.
+	admin.GET("/keys", func(c *gin.Context) {
+		list, err := keys.List()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"keys": list})
+	})
+
+	// POST /admin/keys/:key/revoke revokes a key immediately.
+	admin.POST("/keys/:key/revoke", func(c *gin.Context) {
+		if err := keys.Revoke(c.Param("key")); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "revoked"})
+	})
+
+	// POST /admin/keys/:key/quota { "quota": 100000 } sets a key's daily
+	// token quota.
+	admin.POST("/keys/:key/quota", func(c *gin.Context) {
+		var req struct {
+			Quota int64 `json:"quota"`
+		}
+		if err := c.BindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := keys.SetQuota(c.Param("key"), req.Quota); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "updated"})
+	})
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var apiKeysBucket = []byte("api_keys")
+
+// ErrKeyNotFound is returned when no API key matches the given value.
+var ErrKeyNotFound = errors.New("api key not found")
+
+// APIKey is a provisioned client credential with a daily token quota.
+type APIKey struct {
+	Key       string    `json:"key"`
+	Quota     int64     `json:"quota"`
+	UsedToday int64     `json:"used_today"`
+	QuotaDate string    `json:"quota_date"`
+	Revoked   bool      `json:"revoked"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// KeyStore persists API keys and their quota usage in a bbolt file.
+type KeyStore struct {
+	db *bolt.DB
+}
+
+// NewKeyStore opens (creating if needed) the bbolt file at path.
+func NewKeyStore(path string) (*KeyStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(apiKeysBucket)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &KeyStore{db: db}, nil
+}
+
+// Create provisions a new random API key with the given daily token quota.
+func (s *KeyStore) Create(quota int64) (*APIKey, error) {
+	buf := make([]byte, 20)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	key := &APIKey{
+		Key:       "sk-" + hex.EncodeToString(buf),
+		Quota:     quota,
+		QuotaDate: today(),
+		CreatedAt: time.Now(),
+	}
+	if err := s.put(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Get looks up an API key by its value.
+func (s *KeyStore) Get(key string) (*APIKey, error) {
+	var k APIKey
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(apiKeysBucket).Get([]byte(key))
+		if data == nil {
+			return ErrKeyNotFound
+		}
+		return json.Unmarshal(data, &k)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+// List returns every provisioned key.
+func (s *KeyStore) List() ([]APIKey, error) {
+	var keys []APIKey
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(apiKeysBucket).ForEach(func(_, data []byte) error {
+			var k APIKey
+			if err := json.Unmarshal(data, &k); err != nil {
+				return err
+			}
+			keys = append(keys, k)
+			return nil
+		})
+	})
+	return keys, err
+}
+
+// Revoke marks a key as revoked so it's rejected by the auth middleware.
+func (s *KeyStore) Revoke(key string) error {
+	k, err := s.Get(key)
+	if err != nil {
+		return err
+	}
+	k.Revoked = true
+	return s.put(k)
+}
+
+// SetQuota updates a key's daily token quota.
+func (s *KeyStore) SetQuota(key string, quota int64) error {
+	k, err := s.Get(key)
+	if err != nil {
+		return err
+	}
+	k.Quota = quota
+	return s.put(k)
+}
+
+// AddUsage records tokens spent by key, resetting the counter if it's a new
+// day, and returns the updated key.
+func (s *KeyStore) AddUsage(key string, tokens int64) (*APIKey, error) {
+	k, err := s.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if k.QuotaDate != today() {
+		k.QuotaDate = today()
+		k.UsedToday = 0
+	}
+	k.UsedToday += tokens
+	if err := s.put(k); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+func (s *KeyStore) put(k *APIKey) error {
+	data, err := json.Marshal(k)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(apiKeysBucket).Put([]byte(k.Key), data)
+	})
+}
+
+func today() string {
+	return time.Now().UTC().Format("2006-01-02")
+}
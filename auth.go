@@ -0,0 +1,48 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ctxAPIKey is the gin context key the authenticated *APIKey is stored
+// under by apiKeyAuth.
+const ctxAPIKey = "api_key"
+
+// apiKeyAuth validates the Authorization: Bearer <key> header against
+// keys, rejecting revoked keys and keys that have exceeded their daily
+// quota, and stashes the resolved *APIKey in the context for downstream
+// handlers (rate limiting, usage metering).
+func apiKeyAuth(keys *KeyStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		value := strings.TrimPrefix(header, "Bearer ")
+		if value == "" || value == header {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing Authorization: Bearer <key> header"})
+			c.Abort()
+			return
+		}
+
+		key, err := keys.Get(value)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid api key"})
+			c.Abort()
+			return
+		}
+		if key.Revoked {
+			c.JSON(http.StatusForbidden, gin.H{"error": "api key revoked"})
+			c.Abort()
+			return
+		}
+		if key.Quota > 0 && key.QuotaDate == today() && key.UsedToday >= key.Quota {
+			c.JSON(http.StatusPaymentRequired, gin.H{"error": "daily token quota exceeded"})
+			c.Abort()
+			return
+		}
+
+		c.Set(ctxAPIKey, key)
+		c.Next()
+	}
+}
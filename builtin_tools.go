@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"strings"
+)
+
+// shellAllowlist restricts shell_exec to a fixed set of binaries so the tool
+// can't be used to run arbitrary commands the model hallucinates.
+var shellAllowlist = map[string]bool{
+	"echo": true,
+	"ls":   true,
+	"cat":  true,
+	"pwd":  true,
+	"date": true,
+}
+
+// registerBuiltinTools wires up the small default toolset: http_get,
+// sql_query (against db, may be nil to disable it) and an allowlisted
+// shell_exec.
+func registerBuiltinTools(registry *ToolRegistry, db *sql.DB) {
+	registry.RegisterTool("http_get",
+		json.RawMessage(`{"type":"object","properties":{"url":{"type":"string"}},"required":["url"]}`),
+		toolHTTPGet,
+	)
+
+	registry.RegisterTool("shell_exec",
+		json.RawMessage(`{"type":"object","properties":{"command":{"type":"string"},"args":{"type":"array","items":{"type":"string"}}},"required":["command"]}`),
+		toolShellExec,
+	)
+
+	if db != nil {
+		registry.RegisterTool("sql_query",
+			json.RawMessage(`{"type":"object","properties":{"query":{"type":"string"}},"required":["query"]}`),
+			toolSQLQuery(db),
+		)
+	}
+}
+
+func toolHTTPGet(ctx context.Context, args json.RawMessage) (string, error) {
+	var params struct {
+		URL string `json:"url"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, params.URL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+func toolShellExec(ctx context.Context, args json.RawMessage) (string, error) {
+	var params struct {
+		Command string   `json:"command"`
+		Args    []string `json:"args"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return "", err
+	}
+	if !shellAllowlist[params.Command] {
+		return "", fmt.Errorf("command %q is not in the shell_exec allowlist", params.Command)
+	}
+
+	out, err := exec.CommandContext(ctx, params.Command, params.Args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.Join(append([]string{params.Command}, params.Args...), " "), err)
+	}
+	return string(out), nil
+}
+
+func toolSQLQuery(db *sql.DB) ToolFunc {
+	return func(ctx context.Context, args json.RawMessage) (string, error) {
+		var params struct {
+			Query string `json:"query"`
+		}
+		if err := json.Unmarshal(args, &params); err != nil {
+			return "", err
+		}
+
+		rows, err := db.QueryContext(ctx, params.Query)
+		if err != nil {
+			return "", err
+		}
+		defer rows.Close()
+
+		cols, err := rows.Columns()
+		if err != nil {
+			return "", err
+		}
+
+		var results []map[string]any
+		for rows.Next() {
+			values := make([]any, len(cols))
+			ptrs := make([]any, len(cols))
+			for i := range values {
+				ptrs[i] = &values[i]
+			}
+			if err := rows.Scan(ptrs...); err != nil {
+				return "", err
+			}
+			row := make(map[string]any, len(cols))
+			for i, col := range cols {
+				row[col] = values[i]
+			}
+			results = append(results, row)
+		}
+
+		out, err := json.Marshal(results)
+		if err != nil {
+			return "", err
+		}
+		return string(out), nil
+	}
+}
@@ -3,62 +3,292 @@ package main
 import (
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sashabaranov/go-openai"
+
+	"cursor/providers"
+	"cursor/tokenpool"
 )
 
+// defaultModel is used when a request doesn't specify one.
+var defaultModel = map[string]string{
+	"deepseek":   "deepseek-chat",
+	"openai":     "gpt-4o-mini",
+	"duckduckgo": "gpt-4o-mini",
+}
+
+// defaultProvider is used when a request doesn't specify a provider.
+const defaultProvider = "deepseek"
+
 func main() {
-	apiKey := os.Getenv("DEEPSEEK_API_KEY")
-	if apiKey == "" {
-		panic("DEEPSEEK_API_KEY not set")
+	cfg, err := providers.LoadConfig("providers.yaml")
+	if err != nil {
+		panic(err)
 	}
 
-	client := openai.NewClientWithConfig(openai.ClientConfig{
-		BaseURL: "https://api.deepseek.com/v1",
-		APIKey:  apiKey,
-	})
+	pool := tokenpool.NewPool()
+	if path := os.Getenv("ACCESS_TOKENS_PATH"); path != "" {
+		if err := pool.LoadFile(path); err != nil {
+			panic(err)
+		}
+		cfg.HTTPClient = &http.Client{Transport: tokenpool.NewRoundTripper(pool, nil)}
+	}
+
+	router := providers.BuildRouter(cfg)
+
+	tools := NewToolRegistry()
+	registerBuiltinTools(tools, nil)
+
+	maxHistoryTokens := defaultMaxHistoryTokens
+	if v := os.Getenv("SESSION_MAX_HISTORY_TOKENS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			maxHistoryTokens = n
+		}
+	}
+
+	var sessions SessionStore
+	if path := os.Getenv("SESSION_DB_PATH"); path != "" {
+		store, err := newBoltSessionStore(path, maxHistoryTokens)
+		if err != nil {
+			panic(err)
+		}
+		sessions = store
+	} else {
+		sessions = newMemorySessionStore(maxHistoryTokens)
+	}
+
+	keyPath := os.Getenv("API_KEYS_DB_PATH")
+	if keyPath == "" {
+		keyPath = "api_keys.db"
+	}
+	keys, err := NewKeyStore(keyPath)
+	if err != nil {
+		panic(err)
+	}
+	perKeyLimiter, perIPLimiter := newLimiterSet(), newLimiterSet()
+	go func() {
+		for range time.Tick(limiterIdleTTL) {
+			perKeyLimiter.sweep()
+			perIPLimiter.sweep()
+		}
+	}()
 
 	r := gin.Default()
+	registerAdminRoutes(r, pool, keys)
+	registerMetricsRoute(r)
+
+	api := r.Group("/api", apiKeyAuth(keys), rateLimit(perKeyLimiter, perIPLimiter))
 
 	// health check
 	r.GET("/api/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
 	})
 
-	// POST /api/chat { "prompt": "text" }
-	r.POST("/api/chat", func(c *gin.Context) {
+	// GET /api/models aggregates each registered provider's model list.
+	api.GET("/models", func(c *gin.Context) {
+		models, err := router.AllModels(c)
+		if err != nil {
+			c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"models": models})
+	})
+
+	// POST /api/session { "system": "optional system prompt" }
+	api.POST("/session", func(c *gin.Context) {
+		var req struct {
+			System string `json:"system"`
+		}
+		_ = c.BindJSON(&req)
+
+		sess, err := sessions.Create(req.System)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"session_id": sess.ID})
+	})
+
+	// GET /api/session/:id returns the stored transcript for a session.
+	api.GET("/session/:id", func(c *gin.Context) {
+		sess, err := sessions.Get(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, sess)
+	})
+
+	// POST /api/chat { "prompt": "text", "session_id": "optional", "provider": "deepseek|openai|duckduckgo", "model": "optional", "tools": [...optional OpenAI-style tools...], "use_builtin_tools": false }
+	api.POST("/chat", func(c *gin.Context) {
 		var req struct {
-			Prompt string `json:"prompt"`
+			Prompt          string        `json:"prompt"`
+			SessionID       string        `json:"session_id"`
+			Provider        string        `json:"provider"`
+			Model           string        `json:"model"`
+			Tools           []openai.Tool `json:"tools"`
+			UseBuiltinTools bool          `json:"use_builtin_tools"`
 		}
 		if err := c.BindJSON(&req); err != nil || req.Prompt == "" {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid prompt"})
 			return
 		}
 
-		resp, err := client.CreateChatCompletion(
-			c,
-			openai.ChatCompletionRequest{
-				Model: "deepseek-chat",
-				Messages: []openai.ChatCompletionMessage{
-					{Role: openai.ChatMessageRoleUser, Content: req.Prompt},
-				},
-				Temperature: 0.7,
-			},
-		)
+		providerName, model := resolveProviderAndModel(req.Provider, req.Model)
+		provider, err := router.Get(providerName)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		timer := prometheus.NewTimer(chatLatencySeconds.WithLabelValues(providerName))
+		defer timer.ObserveDuration()
+
+		messages := []openai.ChatCompletionMessage{
+			{Role: openai.ChatMessageRoleUser, Content: req.Prompt},
+		}
+		if req.SessionID != "" {
+			sess, err := sessions.Get(req.SessionID)
+			if err != nil {
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			}
+			if err := sessions.Append(req.SessionID, messages[0]); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			messages = append(sess.Messages, messages[0])
+		}
+
+		reqTools := req.Tools
+		if req.UseBuiltinTools {
+			reqTools = append(reqTools, tools.Definitions()...)
+		}
+		chatReq := providers.ChatRequest{
+			Model:       model,
+			Messages:    messages,
+			Temperature: 0.7,
+			Tools:       reqTools,
+		}
+
+		var reply openai.ChatCompletionMessage
+		var usage openai.Usage
+		if len(chatReq.Tools) > 0 {
+			reply, usage, err = chatCompletionLoop(c, provider, tools, chatReq)
+		} else {
+			var resp providers.ChatResponse
+			resp, err = provider.ChatCompletion(c, chatReq)
+			reply = resp.Message
+			usage = resp.Usage
+		}
 		if err != nil {
+			chatRequestsTotal.WithLabelValues(providerName, "error").Inc()
 			c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
 			return
 		}
+		chatRequestsTotal.WithLabelValues(providerName, "ok").Inc()
+		if usage.TotalTokens > 0 {
+			chatTokensTotal.WithLabelValues(providerName).Add(float64(usage.TotalTokens))
+			if key, ok := c.Get(ctxAPIKey); ok {
+				_, _ = keys.AddUsage(key.(*APIKey).Key, int64(usage.TotalTokens))
+			}
+		}
 
-		if len(resp.Choices) == 0 {
-			c.JSON(http.StatusBadGateway, gin.H{"error": "empty response"})
+		if req.SessionID != "" {
+			if err := sessions.Append(req.SessionID, reply); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{"reply": reply.Content})
+	})
+
+	// POST /api/chat/stream { "prompt": "text", "provider": "...", "model": "..." } - same as
+	// /api/chat but streams tokens to the client as Server-Sent Events instead of
+	// waiting for the full completion.
+	api.POST("/chat/stream", func(c *gin.Context) {
+		var req struct {
+			Prompt   string `json:"prompt"`
+			Provider string `json:"provider"`
+			Model    string `json:"model"`
+		}
+		if err := c.BindJSON(&req); err != nil || req.Prompt == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid prompt"})
+			return
+		}
+
+		providerName, model := resolveProviderAndModel(req.Provider, req.Model)
+		provider, err := router.Get(providerName)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"reply": resp.Choices[0].Message.Content})
+		chunks, err := provider.ChatCompletionStream(c, providers.ChatRequest{
+			Model: model,
+			Messages: []openai.ChatCompletionMessage{
+				{Role: openai.ChatMessageRoleUser, Content: req.Prompt},
+			},
+			Temperature: 0.7,
+		})
+		if err != nil {
+			c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+
+		heartbeat := time.NewTicker(15 * time.Second)
+		defer heartbeat.Stop()
+
+		var usage openai.Usage
+		for {
+			select {
+			case chunk, ok := <-chunks:
+				if !ok {
+					chatRequestsTotal.WithLabelValues(providerName, "ok").Inc()
+					if usage.TotalTokens > 0 {
+						chatTokensTotal.WithLabelValues(providerName).Add(float64(usage.TotalTokens))
+						if key, ok := c.Get(ctxAPIKey); ok {
+							_, _ = keys.AddUsage(key.(*APIKey).Key, int64(usage.TotalTokens))
+						}
+					}
+					c.SSEvent("done", usage)
+					c.Writer.Flush()
+					return
+				}
+				if chunk.Usage != nil {
+					usage = *chunk.Usage
+				}
+				if chunk.Content != "" {
+					c.SSEvent("message", chunk.Content)
+					c.Writer.Flush()
+				}
+			case <-heartbeat.C:
+				c.Writer.WriteString(": heartbeat\n\n")
+				c.Writer.Flush()
+			}
+		}
 	})
 
 	r.Run(":8080")
 }
 
+// resolveProviderAndModel fills in the default provider/model for any field
+// the caller left blank.
+func resolveProviderAndModel(provider, model string) (string, string) {
+	if provider == "" {
+		provider = defaultProvider
+	}
+	if model == "" {
+		model = defaultModel[provider]
+	}
+	return provider, model
+}
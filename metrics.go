@@ -0,0 +1,32 @@
+package main
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	chatRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "chat_requests_total",
+		Help: "Total number of /api/chat requests, labeled by provider and outcome.",
+	}, []string{"provider", "outcome"})
+
+	chatTokensTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "chat_tokens_total",
+		Help: "Total number of tokens billed across /api/chat requests, labeled by provider.",
+	}, []string{"provider"})
+
+	chatLatencySeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "chat_latency_seconds",
+		Help:    "Latency of /api/chat requests in seconds, labeled by provider.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"provider"})
+)
+
+// registerMetricsRoute exposes the default Prometheus registry at /metrics.
+func registerMetricsRoute(r *gin.Engine) {
+	handler := promhttp.Handler()
+	r.GET("/metrics", gin.WrapH(handler))
+}
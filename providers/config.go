@@ -0,0 +1,71 @@
+package providers
+
+import (
+	"net/http"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config describes which providers to wire up and their credentials. It can
+// be loaded from providers.yaml or filled in from environment variables as a
+// fallback (see LoadConfig).
+type Config struct {
+	DeepSeek struct {
+		APIKey  string `yaml:"api_key"`
+		BaseURL string `yaml:"base_url"`
+	} `yaml:"deepseek"`
+	OpenAI struct {
+		APIKey  string `yaml:"api_key"`
+		BaseURL string `yaml:"base_url"`
+	} `yaml:"openai"`
+	DuckDuckGo struct {
+		Enabled bool `yaml:"enabled"`
+	} `yaml:"duckduckgo"`
+
+	// HTTPClient, when set, is used for the DeepSeek and OpenAI providers'
+	// outbound requests instead of the go-openai default - e.g. to route
+	// their traffic through a tokenpool.RoundTripper. DuckDuckGo always uses
+	// http.DefaultClient. Not configurable via providers.yaml; callers set it
+	// on the Config returned by LoadConfig.
+	HTTPClient *http.Client `yaml:"-"`
+}
+
+// LoadConfig reads providers.yaml at path if it exists, otherwise falls back
+// to DEEPSEEK_API_KEY / OPENAI_API_KEY environment variables with DuckDuckGo
+// enabled by default (it needs no credentials).
+func LoadConfig(path string) (Config, error) {
+	var cfg Config
+
+	data, err := os.ReadFile(path)
+	if err == nil {
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return Config{}, err
+		}
+		return cfg, nil
+	}
+	if !os.IsNotExist(err) {
+		return Config{}, err
+	}
+
+	cfg.DeepSeek.APIKey = os.Getenv("DEEPSEEK_API_KEY")
+	cfg.OpenAI.APIKey = os.Getenv("OPENAI_API_KEY")
+	cfg.DuckDuckGo.Enabled = true
+	return cfg, nil
+}
+
+// BuildRouter registers a provider for every backend with usable credentials
+// (DuckDuckGo whenever it is enabled, since it's anonymous).
+func BuildRouter(cfg Config) *Router {
+	r := NewRouter()
+	if cfg.DeepSeek.APIKey != "" {
+		r.Register(NewDeepSeek(cfg.DeepSeek.APIKey, cfg.DeepSeek.BaseURL, cfg.HTTPClient))
+	}
+	if cfg.OpenAI.APIKey != "" {
+		r.Register(NewOpenAI(cfg.OpenAI.APIKey, cfg.OpenAI.BaseURL, cfg.HTTPClient))
+	}
+	if cfg.DuckDuckGo.Enabled {
+		r.Register(NewDuckDuckGo())
+	}
+	return r
+}
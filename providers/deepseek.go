@@ -0,0 +1,106 @@
+package providers
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// DeepSeekBaseURL is the default API base for the DeepSeek backend.
+const DeepSeekBaseURL = "https://api.deepseek.com/v1"
+
+// deepSeekProvider talks to DeepSeek's OpenAI-compatible chat completions API.
+type deepSeekProvider struct {
+	client      *openai.Client
+	models      []string
+	maxAttempts int
+}
+
+// NewDeepSeek builds a Provider backed by the DeepSeek API. httpClient may be
+// nil to use the go-openai default; pass one wrapping a
+// tokenpool.RoundTripper to rotate bearer tokens per request.
+func NewDeepSeek(apiKey, baseURL string, httpClient *http.Client) Provider {
+	if baseURL == "" {
+		baseURL = DeepSeekBaseURL
+	}
+	clientCfg := openai.ClientConfig{
+		BaseURL: baseURL,
+		APIKey:  apiKey,
+	}
+	if httpClient != nil {
+		clientCfg.HTTPClient = httpClient
+	}
+	client := openai.NewClientWithConfig(clientCfg)
+	return &deepSeekProvider{
+		client:      client,
+		models:      []string{"deepseek-chat", "deepseek-reasoner"},
+		maxAttempts: 3,
+	}
+}
+
+func (p *deepSeekProvider) Name() string { return "deepseek" }
+
+func (p *deepSeekProvider) Models(ctx context.Context) ([]string, error) {
+	return p.models, nil
+}
+
+func (p *deepSeekProvider) ChatCompletion(ctx context.Context, req ChatRequest) (ChatResponse, error) {
+	var resp openai.ChatCompletionResponse
+	err := withRetry(ctx, p.maxAttempts, func() error {
+		var err error
+		resp, err = p.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+			Model:       req.Model,
+			Messages:    req.Messages,
+			Temperature: req.Temperature,
+			Tools:       req.Tools,
+		})
+		return err
+	})
+	if err != nil {
+		return ChatResponse{}, err
+	}
+	if len(resp.Choices) == 0 {
+		return ChatResponse{}, errEmptyResponse
+	}
+	return ChatResponse{Message: resp.Choices[0].Message, Usage: resp.Usage}, nil
+}
+
+func (p *deepSeekProvider) ChatCompletionStream(ctx context.Context, req ChatRequest) (<-chan StreamChunk, error) {
+	stream, err := p.client.CreateChatCompletionStream(ctx, openai.ChatCompletionRequest{
+		Model:         req.Model,
+		Messages:      req.Messages,
+		Temperature:   req.Temperature,
+		Tools:         req.Tools,
+		Stream:        true,
+		StreamOptions: &openai.StreamOptions{IncludeUsage: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan StreamChunk)
+	go func() {
+		defer close(out)
+		defer stream.Close()
+		for {
+			chunk, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				return
+			}
+			sc := StreamChunk{}
+			if chunk.Usage != nil {
+				sc.Usage = chunk.Usage
+			}
+			if len(chunk.Choices) > 0 {
+				sc.Content = chunk.Choices[0].Delta.Content
+			}
+			out <- sc
+		}
+	}()
+	return out, nil
+}
@@ -0,0 +1,164 @@
+package providers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+const (
+	duckduckgoBaseURL    = "https://duckduckgo.com"
+	duckduckgoStatusPath = "/duckchat/v1/status"
+	duckduckgoChatPath   = "/duckchat/v1/chat"
+)
+
+// duckDuckGoProvider talks to DuckDuckGo's anonymous AI chat gateway. It has
+// no concept of an API key: every request needs a fresh "x-vqd-4" challenge
+// token fetched from the status endpoint.
+type duckDuckGoProvider struct {
+	httpClient *http.Client
+	baseURL    string
+
+	mu  sync.Mutex
+	vqd string
+}
+
+// NewDuckDuckGo builds a Provider backed by DuckDuckGo's duckchat gateway.
+func NewDuckDuckGo() Provider {
+	return &duckDuckGoProvider{httpClient: http.DefaultClient, baseURL: duckduckgoBaseURL}
+}
+
+func (p *duckDuckGoProvider) Name() string { return "duckduckgo" }
+
+func (p *duckDuckGoProvider) Models(ctx context.Context) ([]string, error) {
+	return []string{"gpt-4o-mini", "claude-3-haiku", "llama-3.1-70b", "mixtral-8x7b"}, nil
+}
+
+// vqdToken fetches (and caches) the x-vqd-4 challenge token required by the
+// chat endpoint. The token is short-lived, so callers refresh it per request
+// rather than trusting the cache across a long session.
+func (p *duckDuckGoProvider) vqdToken(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+duckduckgoStatusPath, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("x-vqd-accept", "1")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("duckduckgo status check failed: %s", resp.Status)
+	}
+	vqd := resp.Header.Get("x-vqd-4")
+	if vqd == "" {
+		return "", fmt.Errorf("duckduckgo status response missing x-vqd-4 header")
+	}
+
+	p.mu.Lock()
+	p.vqd = vqd
+	p.mu.Unlock()
+	return vqd, nil
+}
+
+func duckduckgoBody(req ChatRequest) ([]byte, error) {
+	type ddgMessage struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	}
+	messages := make([]ddgMessage, 0, len(req.Messages))
+	for _, m := range req.Messages {
+		messages = append(messages, ddgMessage{Role: m.Role, Content: m.Content})
+	}
+	return json.Marshal(struct {
+		Model    string       `json:"model"`
+		Messages []ddgMessage `json:"messages"`
+	}{Model: req.Model, Messages: messages})
+}
+
+func (p *duckDuckGoProvider) ChatCompletion(ctx context.Context, req ChatRequest) (ChatResponse, error) {
+	var chunks <-chan StreamChunk
+	err := withRetry(ctx, 3, func() error {
+		var err error
+		chunks, err = p.ChatCompletionStream(ctx, req)
+		return err
+	})
+	if err != nil {
+		return ChatResponse{}, err
+	}
+	var sb strings.Builder
+	var usage openai.Usage
+	for chunk := range chunks {
+		sb.WriteString(chunk.Content)
+		if chunk.Usage != nil {
+			usage = *chunk.Usage
+		}
+	}
+	return ChatResponse{
+		Message: openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: sb.String()},
+		Usage:   usage,
+	}, nil
+}
+
+// ChatCompletionStream issues the chat request and parses the
+// newline-delimited JSON response into StreamChunks.
+func (p *duckDuckGoProvider) ChatCompletionStream(ctx context.Context, req ChatRequest) (<-chan StreamChunk, error) {
+	vqd, err := p.vqdToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := duckduckgoBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+duckduckgoChatPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("x-vqd-4", vqd)
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("duckduckgo chat failed: %s", resp.Status)
+	}
+
+	out := make(chan StreamChunk)
+	go func() {
+		defer close(out)
+		defer resp.Body.Close()
+
+		var ddgChunk struct {
+			Message string `json:"message"`
+			Done    bool   `json:"done"`
+		}
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			if len(line) == 0 {
+				continue
+			}
+			if err := json.Unmarshal(line, &ddgChunk); err != nil {
+				continue
+			}
+			out <- StreamChunk{Content: ddgChunk.Message, Done: ddgChunk.Done}
+		}
+	}()
+	return out, nil
+}
@@ -0,0 +1,10 @@
+package providers
+
+import "errors"
+
+// errEmptyResponse is returned when a backend replies with no choices.
+var errEmptyResponse = errors.New("provider returned an empty response")
+
+// ErrUnknownProvider is returned by Router.Get when no provider is
+// registered under the requested name.
+var ErrUnknownProvider = errors.New("unknown provider")
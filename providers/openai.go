@@ -0,0 +1,103 @@
+package providers
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// openAIProvider talks to the stock OpenAI chat completions API.
+type openAIProvider struct {
+	client      *openai.Client
+	maxAttempts int
+}
+
+// NewOpenAI builds a Provider backed by the OpenAI API. baseURL may be empty
+// to use OpenAI's default. httpClient may be nil to use the go-openai
+// default; pass one wrapping a tokenpool.RoundTripper to rotate bearer
+// tokens per request.
+func NewOpenAI(apiKey, baseURL string, httpClient *http.Client) Provider {
+	cfg := openai.DefaultConfig(apiKey)
+	if baseURL != "" {
+		cfg.BaseURL = baseURL
+	}
+	if httpClient != nil {
+		cfg.HTTPClient = httpClient
+	}
+	return &openAIProvider{client: openai.NewClientWithConfig(cfg), maxAttempts: 3}
+}
+
+func (p *openAIProvider) Name() string { return "openai" }
+
+func (p *openAIProvider) Models(ctx context.Context) ([]string, error) {
+	list, err := p.client.ListModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(list.Models))
+	for _, m := range list.Models {
+		names = append(names, m.ID)
+	}
+	return names, nil
+}
+
+func (p *openAIProvider) ChatCompletion(ctx context.Context, req ChatRequest) (ChatResponse, error) {
+	var resp openai.ChatCompletionResponse
+	err := withRetry(ctx, p.maxAttempts, func() error {
+		var err error
+		resp, err = p.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+			Model:       req.Model,
+			Messages:    req.Messages,
+			Temperature: req.Temperature,
+			Tools:       req.Tools,
+		})
+		return err
+	})
+	if err != nil {
+		return ChatResponse{}, err
+	}
+	if len(resp.Choices) == 0 {
+		return ChatResponse{}, errEmptyResponse
+	}
+	return ChatResponse{Message: resp.Choices[0].Message, Usage: resp.Usage}, nil
+}
+
+func (p *openAIProvider) ChatCompletionStream(ctx context.Context, req ChatRequest) (<-chan StreamChunk, error) {
+	stream, err := p.client.CreateChatCompletionStream(ctx, openai.ChatCompletionRequest{
+		Model:         req.Model,
+		Messages:      req.Messages,
+		Temperature:   req.Temperature,
+		Tools:         req.Tools,
+		Stream:        true,
+		StreamOptions: &openai.StreamOptions{IncludeUsage: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan StreamChunk)
+	go func() {
+		defer close(out)
+		defer stream.Close()
+		for {
+			chunk, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				return
+			}
+			sc := StreamChunk{}
+			if chunk.Usage != nil {
+				sc.Usage = chunk.Usage
+			}
+			if len(chunk.Choices) > 0 {
+				sc.Content = chunk.Choices[0].Delta.Content
+			}
+			out <- sc
+		}
+	}()
+	return out, nil
+}
@@ -0,0 +1,49 @@
+// Package providers abstracts over chat completion backends (DeepSeek,
+// OpenAI, anonymous DuckDuckGo-style gateways, ...) behind a single
+// Provider interface so the HTTP layer can route requests by name instead
+// of hard-coding a single client.
+package providers
+
+import (
+	"context"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// ChatRequest is the provider-agnostic shape of a completion request. The
+// DeepSeek and OpenAI backends use every field; DuckDuckGo sends only Model
+// and Messages. Provider-specific options live in each implementation.
+type ChatRequest struct {
+	Model       string
+	Messages    []openai.ChatCompletionMessage
+	Temperature float32
+	Tools       []openai.Tool
+}
+
+// ChatResponse is a completed, non-streamed reply.
+type ChatResponse struct {
+	Message openai.ChatCompletionMessage
+	Usage   openai.Usage
+}
+
+// StreamChunk is one piece of a streamed reply. Usage is set only on the
+// chunk that carries the backend's usage report (DeepSeek and OpenAI send it
+// last; DuckDuckGo never does). Done is set only by DuckDuckGo, which marks
+// its final chunk in-band. Every stream ends by closing its channel.
+type StreamChunk struct {
+	Content string
+	Usage   *openai.Usage
+	Done    bool
+}
+
+// Provider is a chat completion backend. Implementations must be safe for
+// concurrent use.
+type Provider interface {
+	// Name is the identifier used in the "provider" field of API requests
+	// and as the registration key in a Router (e.g. "deepseek").
+	Name() string
+	ChatCompletion(ctx context.Context, req ChatRequest) (ChatResponse, error)
+	ChatCompletionStream(ctx context.Context, req ChatRequest) (<-chan StreamChunk, error)
+	// Models lists the model identifiers this provider currently serves.
+	Models(ctx context.Context) ([]string, error)
+}
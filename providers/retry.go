@@ -0,0 +1,30 @@
+package providers
+
+import (
+	"context"
+	"time"
+)
+
+// withRetry retries fn with exponential backoff (200ms, 400ms, 800ms, ...),
+// giving up after maxAttempts or when ctx is cancelled. It's shared by every
+// Provider implementation so backoff behavior stays consistent across
+// backends.
+func withRetry(ctx context.Context, maxAttempts int, fn func() error) error {
+	var err error
+	backoff := 200 * time.Millisecond
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		if attempt == maxAttempts-1 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	return err
+}
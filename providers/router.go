@@ -0,0 +1,42 @@
+package providers
+
+import "context"
+
+// Router dispatches chat requests to a named Provider, so the HTTP layer
+// only needs to know a string like "deepseek" rather than a concrete type.
+type Router struct {
+	providers map[string]Provider
+}
+
+// NewRouter builds an empty Router. Use Register to add providers.
+func NewRouter() *Router {
+	return &Router{providers: make(map[string]Provider)}
+}
+
+// Register adds a provider, keyed by its Name().
+func (r *Router) Register(p Provider) {
+	r.providers[p.Name()] = p
+}
+
+// Get returns the provider registered under name, or ErrUnknownProvider.
+func (r *Router) Get(name string) (Provider, error) {
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+	return p, nil
+}
+
+// AllModels aggregates the model list of every registered provider, keyed by
+// provider name, for the /api/models endpoint.
+func (r *Router) AllModels(ctx context.Context) (map[string][]string, error) {
+	out := make(map[string][]string, len(r.providers))
+	for name, p := range r.providers {
+		models, err := p.Models(ctx)
+		if err != nil {
+			return nil, err
+		}
+		out[name] = models
+	}
+	return out, nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+// requestsPerSecond and burst bound how fast a single key or IP may call the
+// chat endpoints; generous enough for interactive use, tight enough to stop
+// a runaway client from starving everyone else.
+const (
+	requestsPerSecond = 5
+	burst             = 10
+)
+
+// limiterIdleTTL is how long a limiter may sit unused before sweep() evicts
+// it, so a gateway that sees many distinct keys/IPs over time doesn't grow
+// its limiter maps without bound.
+const limiterIdleTTL = time.Hour
+
+type limiterEntry struct {
+	limiter    *rate.Limiter
+	lastUsedAt time.Time
+}
+
+// limiterSet lazily creates and caches a token-bucket limiter per identifier
+// (an API key or a client IP), evicting entries idle longer than
+// limiterIdleTTL.
+type limiterSet struct {
+	mu       sync.Mutex
+	limiters map[string]*limiterEntry
+}
+
+func newLimiterSet() *limiterSet {
+	return &limiterSet{limiters: make(map[string]*limiterEntry)}
+}
+
+func (s *limiterSet) get(id string) *rate.Limiter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.limiters[id]
+	if !ok {
+		e = &limiterEntry{limiter: rate.NewLimiter(requestsPerSecond, burst)}
+		s.limiters[id] = e
+	}
+	e.lastUsedAt = time.Now()
+	return e.limiter
+}
+
+// sweep removes limiters that haven't been used in limiterIdleTTL.
+func (s *limiterSet) sweep() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, e := range s.limiters {
+		if time.Since(e.lastUsedAt) > limiterIdleTTL {
+			delete(s.limiters, id)
+		}
+	}
+}
+
+// rateLimit enforces a token-bucket limit per API key (set by apiKeyAuth)
+// and, as a second line of defense, per client IP.
+func rateLimit(perKey, perIP *limiterSet) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if key, ok := c.Get(ctxAPIKey); ok {
+			if !perKey.get(key.(*APIKey).Key).Allow() {
+				c.JSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded for this API key"})
+				c.Abort()
+				return
+			}
+		}
+		if !perIP.get(c.ClientIP()).Allow() {
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded for this IP"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
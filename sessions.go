@@ -0,0 +1,189 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sashabaranov/go-openai"
+	bolt "go.etcd.io/bbolt"
+)
+
+// ErrSessionNotFound is returned by SessionStore.Get when no session exists
+// for the given id.
+var ErrSessionNotFound = errors.New("session not found")
+
+// Session is a persisted multi-turn conversation.
+type Session struct {
+	ID        string                         `json:"id"`
+	System    string                         `json:"system,omitempty"`
+	Messages  []openai.ChatCompletionMessage `json:"messages"`
+	CreatedAt time.Time                      `json:"created_at"`
+}
+
+// SessionStore persists conversation histories across requests. Implementations
+// must be safe for concurrent use.
+type SessionStore interface {
+	Create(system string) (*Session, error)
+	Get(id string) (*Session, error)
+	Append(id string, msg openai.ChatCompletionMessage) error
+}
+
+// defaultMaxHistoryTokens is the max-history-window fallback used when a
+// store is built without an explicit limit (e.g. via SESSION_MAX_HISTORY_TOKENS).
+const defaultMaxHistoryTokens = 4000
+
+// truncateHistory drops the oldest non-system messages until the
+// approximate token count of the remaining history fits within maxTokens.
+// It's a rough approximation (len(content)/4) rather than an exact
+// tokenizer count, which is good enough for sliding-window truncation.
+func truncateHistory(messages []openai.ChatCompletionMessage, maxTokens int) []openai.ChatCompletionMessage {
+	total := 0
+	for _, m := range messages {
+		total += len(m.Content) / 4
+	}
+	kept := make([]openai.ChatCompletionMessage, 0, len(messages))
+	for _, m := range messages {
+		if total > maxTokens && m.Role != openai.ChatMessageRoleSystem {
+			total -= len(m.Content) / 4
+			continue
+		}
+		kept = append(kept, m)
+	}
+	return kept
+}
+
+// memorySessionStore is the default SessionStore, backed by an in-process map.
+// It does not survive process restarts.
+type memorySessionStore struct {
+	mu               sync.Mutex
+	sessions         map[string]*Session
+	maxHistoryTokens int
+}
+
+func newMemorySessionStore(maxHistoryTokens int) *memorySessionStore {
+	return &memorySessionStore{sessions: make(map[string]*Session), maxHistoryTokens: maxHistoryTokens}
+}
+
+func (s *memorySessionStore) Create(system string) (*Session, error) {
+	sess := &Session{ID: uuid.NewString(), System: system, CreatedAt: time.Now()}
+	if system != "" {
+		sess.Messages = append(sess.Messages, openai.ChatCompletionMessage{
+			Role:    openai.ChatMessageRoleSystem,
+			Content: system,
+		})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[sess.ID] = sess
+	return sess, nil
+}
+
+func (s *memorySessionStore) Get(id string) (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sessions[id]
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+	cp := *sess
+	cp.Messages = append([]openai.ChatCompletionMessage(nil), sess.Messages...)
+	return &cp, nil
+}
+
+func (s *memorySessionStore) Append(id string, msg openai.ChatCompletionMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sessions[id]
+	if !ok {
+		return ErrSessionNotFound
+	}
+	sess.Messages = truncateHistory(append(sess.Messages, msg), s.maxHistoryTokens)
+	return nil
+}
+
+// boltSessionStore persists sessions to a bbolt file, one JSON-encoded
+// Session per key in the "sessions" bucket.
+type boltSessionStore struct {
+	db               *bolt.DB
+	bucket           []byte
+	maxHistoryTokens int
+}
+
+func newBoltSessionStore(path string, maxHistoryTokens int) (*boltSessionStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+	bucket := []byte("sessions")
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucket)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &boltSessionStore{db: db, bucket: bucket, maxHistoryTokens: maxHistoryTokens}, nil
+}
+
+func (s *boltSessionStore) Create(system string) (*Session, error) {
+	sess := &Session{ID: uuid.NewString(), System: system, CreatedAt: time.Now()}
+	if system != "" {
+		sess.Messages = append(sess.Messages, openai.ChatCompletionMessage{
+			Role:    openai.ChatMessageRoleSystem,
+			Content: system,
+		})
+	}
+	if err := s.put(sess); err != nil {
+		return nil, err
+	}
+	return sess, nil
+}
+
+func (s *boltSessionStore) Get(id string) (*Session, error) {
+	var sess Session
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(s.bucket).Get([]byte(id))
+		if data == nil {
+			return ErrSessionNotFound
+		}
+		return json.Unmarshal(data, &sess)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sess, nil
+}
+
+func (s *boltSessionStore) Append(id string, msg openai.ChatCompletionMessage) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return ErrSessionNotFound
+		}
+		var sess Session
+		if err := json.Unmarshal(data, &sess); err != nil {
+			return err
+		}
+		sess.Messages = truncateHistory(append(sess.Messages, msg), s.maxHistoryTokens)
+		out, err := json.Marshal(&sess)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(id), out)
+	})
+}
+
+func (s *boltSessionStore) put(sess *Session) error {
+	data, err := json.Marshal(sess)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(s.bucket).Put([]byte(sess.ID), data)
+	})
+}
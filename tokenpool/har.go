@@ -0,0 +1,75 @@
+package tokenpool
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// harFile is the subset of the HAR 1.2 format needed to recover auth
+// material from a browser's recorded network log.
+type harFile struct {
+	Log struct {
+		Entries []struct {
+			Request struct {
+				Headers []harHeader `json:"headers"`
+				Cookies []harHeader `json:"cookies"`
+			} `json:"request"`
+		} `json:"entries"`
+	} `json:"log"`
+}
+
+type harHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ParseHAR extracts one Token per distinct Authorization header found across
+// a HAR file's recorded requests, attaching any puid cookie or x-vqd-4
+// header seen alongside it.
+func ParseHAR(data []byte) ([]*Token, error) {
+	var har harFile
+	if err := json.Unmarshal(data, &har); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]*Token)
+	var order []string
+	for _, entry := range har.Log.Entries {
+		var bearer, puid, vqd string
+		for _, h := range entry.Request.Headers {
+			switch strings.ToLower(h.Name) {
+			case "authorization":
+				bearer = strings.TrimPrefix(h.Value, "Bearer ")
+			case "x-vqd-4":
+				vqd = h.Value
+			}
+		}
+		for _, c := range entry.Request.Cookies {
+			if strings.EqualFold(c.Name, "puid") {
+				puid = c.Value
+			}
+		}
+
+		if bearer == "" {
+			continue
+		}
+		tok, ok := seen[bearer]
+		if !ok {
+			tok = &Token{Value: bearer}
+			seen[bearer] = tok
+			order = append(order, bearer)
+		}
+		if puid != "" {
+			tok.PUID = puid
+		}
+		if vqd != "" {
+			tok.VQD = vqd
+		}
+	}
+
+	tokens := make([]*Token, 0, len(order))
+	for _, bearer := range order {
+		tokens = append(tokens, seen[bearer])
+	}
+	return tokens, nil
+}
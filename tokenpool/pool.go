@@ -0,0 +1,173 @@
+// Package tokenpool manages a rotating set of bearer tokens scraped from
+// access_tokens.json or recorded HAR files, for OpenAI-compatible providers
+// (DeepSeek, OpenAI) that authenticate with short-lived, browser-issued
+// tokens rather than a stable API key.
+package tokenpool
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// cooldown is how long a token is skipped after a 401/429 before it's
+// retried.
+const cooldown = 10 * time.Minute
+
+// refreshInterval is how often LoadFile is asked to re-read the token
+// source from disk, picking up tokens added since startup.
+const refreshInterval = 24 * time.Hour
+
+// ErrNoHealthyTokens is returned by Next when every token in the pool is
+// currently in its cooldown window.
+var ErrNoHealthyTokens = errors.New("tokenpool: no healthy tokens available")
+
+// Token is a single bearer credential plus the auxiliary headers some
+// backends (DuckDuckGo) require alongside it.
+type Token struct {
+	Value     string `json:"value"`
+	PUID      string `json:"puid,omitempty"`
+	VQD       string `json:"vqd,omitempty"`
+	deadUntil time.Time
+}
+
+// Healthy reports whether the token is past its cooldown.
+func (t *Token) Healthy() bool {
+	return time.Now().After(t.deadUntil)
+}
+
+// Status is the admin-facing view of a token's health, omitting the token
+// value itself.
+type Status struct {
+	Index     int       `json:"index"`
+	Healthy   bool      `json:"healthy"`
+	DeadUntil time.Time `json:"dead_until,omitempty"`
+}
+
+// Pool round-robins over a set of tokens, skipping any that are in cooldown
+// after a 401/429, and periodically reloads from its source file.
+type Pool struct {
+	mu     sync.Mutex
+	tokens []*Token
+	next   int
+
+	path       string
+	lastLoaded time.Time
+}
+
+// NewPool builds an empty pool. Use LoadFile or Add to populate it.
+func NewPool() *Pool {
+	return &Pool{}
+}
+
+// LoadFile replaces the pool's tokens with those parsed from path, which may
+// be either an access_tokens.json file (a JSON array of token strings or
+// Token objects) or a HAR file (see ParseHAR). The pool re-reads this path
+// automatically every refreshInterval.
+func (p *Pool) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var tokens []*Token
+	if looksLikeHAR(data) {
+		tokens, err = ParseHAR(data)
+	} else {
+		tokens, err = parseAccessTokens(data)
+	}
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.tokens = tokens
+	p.next = 0
+	p.path = path
+	p.lastLoaded = time.Now()
+	p.mu.Unlock()
+	return nil
+}
+
+func parseAccessTokens(data []byte) ([]*Token, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	tokens := make([]*Token, 0, len(raw))
+	for _, item := range raw {
+		var s string
+		if err := json.Unmarshal(item, &s); err == nil {
+			tokens = append(tokens, &Token{Value: s})
+			continue
+		}
+		var t Token
+		if err := json.Unmarshal(item, &t); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, &t)
+	}
+	return tokens, nil
+}
+
+func looksLikeHAR(data []byte) bool {
+	var probe struct {
+		Log json.RawMessage `json:"log"`
+	}
+	return json.Unmarshal(data, &probe) == nil && len(probe.Log) > 0
+}
+
+// maybeRefresh reloads from the pool's source file if refreshInterval has
+// elapsed since the last load. Called with p.mu held.
+func (p *Pool) maybeRefresh() {
+	if p.path == "" || time.Since(p.lastLoaded) < refreshInterval {
+		return
+	}
+	p.mu.Unlock()
+	_ = p.LoadFile(p.path)
+	p.mu.Lock()
+}
+
+// Next returns the next healthy token in round-robin order.
+func (p *Pool) Next() (*Token, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.maybeRefresh()
+
+	if len(p.tokens) == 0 {
+		return nil, ErrNoHealthyTokens
+	}
+	for i := 0; i < len(p.tokens); i++ {
+		idx := (p.next + i) % len(p.tokens)
+		if p.tokens[idx].Healthy() {
+			p.next = (idx + 1) % len(p.tokens)
+			return p.tokens[idx], nil
+		}
+	}
+	return nil, ErrNoHealthyTokens
+}
+
+// MarkDead puts the given token into cooldown after a 401/429 response.
+func (p *Pool) MarkDead(value string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, t := range p.tokens {
+		if t.Value == value {
+			t.deadUntil = time.Now().Add(cooldown)
+			return
+		}
+	}
+}
+
+// List returns the health of every token in the pool, for the admin API.
+func (p *Pool) List() []Status {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]Status, len(p.tokens))
+	for i, t := range p.tokens {
+		out[i] = Status{Index: i, Healthy: t.Healthy(), DeadUntil: t.deadUntil}
+	}
+	return out
+}
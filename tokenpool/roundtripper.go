@@ -0,0 +1,45 @@
+package tokenpool
+
+import "net/http"
+
+// RoundTripper swaps in the pool's next healthy token as the Authorization
+// header of each outgoing request, and retires the token on a 401/429
+// response so the next request picks a different one.
+type RoundTripper struct {
+	Pool *Pool
+	Base http.RoundTripper
+}
+
+// NewRoundTripper wraps base (http.DefaultTransport if nil) with token
+// rotation from pool.
+func NewRoundTripper(pool *Pool, base http.RoundTripper) *RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &RoundTripper{Pool: pool, Base: base}
+}
+
+func (rt *RoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := rt.Pool.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token.Value)
+	if token.PUID != "" {
+		req.Header.Set("x-puid", token.PUID)
+	}
+	if token.VQD != "" {
+		req.Header.Set("x-vqd-4", token.VQD)
+	}
+
+	resp, err := rt.Base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusTooManyRequests {
+		rt.Pool.MarkDead(token.Value)
+	}
+	return resp, nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/sashabaranov/go-openai"
+
+	"cursor/providers"
+)
+
+// maxToolRounds bounds how many times the dispatcher will feed tool outputs
+// back into the model before giving up, in case a model keeps requesting
+// tools forever.
+const maxToolRounds = 8
+
+// ToolFunc implements a single callable tool. args is the raw JSON arguments
+// object the model produced; the return value is fed back to the model as
+// the tool's output.
+type ToolFunc func(ctx context.Context, args json.RawMessage) (string, error)
+
+// ToolRegistry holds the tools a deployment exposes to the model, keyed by
+// name. The zero value is not usable; use NewToolRegistry.
+type ToolRegistry struct {
+	mu    sync.RWMutex
+	tools map[string]registeredTool
+}
+
+type registeredTool struct {
+	schema json.RawMessage
+	fn     ToolFunc
+}
+
+// NewToolRegistry builds an empty registry.
+func NewToolRegistry() *ToolRegistry {
+	return &ToolRegistry{tools: make(map[string]registeredTool)}
+}
+
+// RegisterTool adds a tool under name, described to the model by schema (an
+// OpenAI function-parameters JSON schema) and implemented by fn.
+func (r *ToolRegistry) RegisterTool(name string, schema json.RawMessage, fn ToolFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tools[name] = registeredTool{schema: schema, fn: fn}
+}
+
+// Definitions returns the OpenAI tool definitions for every registered tool,
+// suitable for ChatCompletionRequest.Tools.
+func (r *ToolRegistry) Definitions() []openai.Tool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	defs := make([]openai.Tool, 0, len(r.tools))
+	for name, t := range r.tools {
+		defs = append(defs, openai.Tool{
+			Type: openai.ToolTypeFunction,
+			Function: &openai.FunctionDefinition{
+				Name:       name,
+				Parameters: t.schema,
+			},
+		})
+	}
+	return defs
+}
+
+// Call invokes the named tool, returning an error if it isn't registered.
+func (r *ToolRegistry) Call(ctx context.Context, name string, args json.RawMessage) (string, error) {
+	r.mu.RLock()
+	tool, ok := r.tools[name]
+	r.mu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("unknown tool %q", name)
+	}
+	return tool.fn(ctx, args)
+}
+
+// chatCompletionLoop sends req to provider, and whenever the reply contains
+// tool_calls, executes them through registry and loops the results back in
+// as tool messages until the model returns a plain assistant message (or
+// maxToolRounds is hit).
+func chatCompletionLoop(ctx context.Context, provider providers.Provider, registry *ToolRegistry, req providers.ChatRequest) (openai.ChatCompletionMessage, openai.Usage, error) {
+	messages := append([]openai.ChatCompletionMessage(nil), req.Messages...)
+	var usage openai.Usage
+
+	for round := 0; round < maxToolRounds; round++ {
+		req.Messages = messages
+		resp, err := provider.ChatCompletion(ctx, req)
+		if err != nil {
+			return openai.ChatCompletionMessage{}, usage, err
+		}
+		usage.PromptTokens += resp.Usage.PromptTokens
+		usage.CompletionTokens += resp.Usage.CompletionTokens
+		usage.TotalTokens += resp.Usage.TotalTokens
+
+		if len(resp.Message.ToolCalls) == 0 {
+			return resp.Message, usage, nil
+		}
+
+		messages = append(messages, resp.Message)
+		for _, call := range resp.Message.ToolCalls {
+			output, err := registry.Call(ctx, call.Function.Name, json.RawMessage(call.Function.Arguments))
+			if err != nil {
+				output = fmt.Sprintf("error: %s", err)
+			}
+			messages = append(messages, openai.ChatCompletionMessage{
+				Role:       openai.ChatMessageRoleTool,
+				Content:    output,
+				ToolCallID: call.ID,
+			})
+		}
+	}
+
+	return openai.ChatCompletionMessage{}, usage, fmt.Errorf("exceeded %d tool-call rounds without a final answer", maxToolRounds)
+}